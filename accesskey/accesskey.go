@@ -0,0 +1,83 @@
+// Package accesskey implements per-user access keys that replace the
+// server's single shared Basic Auth credential. Each key is scoped to a
+// bucket-key prefix, a set of allowed operations, and an optional expiry,
+// so multiple tenants can share one bucket without seeing each other's
+// objects.
+package accesskey
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Operation is one of the actions a Key can be scoped to.
+type Operation string
+
+const (
+	OpRead   Operation = "read"
+	OpWrite  Operation = "write"
+	OpDelete Operation = "delete"
+	OpList   Operation = "list"
+)
+
+// Key is a single access-key/secret-key pair and its scope.
+type Key struct {
+	ID         string      `json:"id"`
+	AccessKey  string      `json:"accessKey"`
+	SecretKey  string      `json:"secretKey"`
+	Prefix     string      `json:"prefix"`
+	Operations []Operation `json:"operations"`
+	CreatedAt  time.Time   `json:"createdAt"`
+	ExpiresAt  time.Time   `json:"expiresAt,omitempty"`
+}
+
+// Expired reports whether the key is past its expiry. A zero ExpiresAt
+// means the key never expires.
+func (k *Key) Expired() bool {
+	return !k.ExpiresAt.IsZero() && time.Now().After(k.ExpiresAt)
+}
+
+// Allows reports whether the key's scope permits op.
+func (k *Key) Allows(op Operation) bool {
+	for _, allowed := range k.Operations {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+// Store persists access keys. BoltStore is the default implementation;
+// another (e.g. a SQLite-backed one) can be swapped in by satisfying this
+// interface.
+type Store interface {
+	Create(key *Key) error
+	Get(accessKey string) (*Key, error)
+	List() ([]*Key, error)
+	Delete(id string) error
+	Close() error
+}
+
+// GenerateKeyPair returns a new random (access key, secret key) pair: an
+// 8-character access key and a 32-character secret key, both lowercase hex.
+func GenerateKeyPair() (accessKey, secretKey string, err error) {
+	accessKey, err = randomHex(4)
+	if err != nil {
+		return "", "", err
+	}
+	secretKey, err = randomHex(16)
+	if err != nil {
+		return "", "", err
+	}
+	return accessKey, secretKey, nil
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
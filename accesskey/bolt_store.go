@@ -0,0 +1,112 @@
+package accesskey
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.etcd.io/bbolt"
+)
+
+var keysBucket = []byte("access_keys")
+
+// BoltStore is a Store backed by a local BoltDB file.
+type BoltStore struct {
+	db *bbolt.DB
+}
+
+// NewBoltStore opens (creating if needed) a BoltDB-backed key store at path.
+func NewBoltStore(path string) (*BoltStore, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create access key database directory: %w", err)
+		}
+	}
+
+	db, err := bbolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open access key database: %w", err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(keysBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize access key database: %w", err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// Create persists a new key, keyed by its AccessKey.
+func (s *BoltStore) Create(key *Key) error {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("failed to encode access key: %w", err)
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(keysBucket).Put([]byte(key.AccessKey), data)
+	})
+}
+
+// Get looks up a key by its AccessKey.
+func (s *BoltStore) Get(accessKey string) (*Key, error) {
+	var key Key
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(keysBucket).Get([]byte(accessKey))
+		if data == nil {
+			return fmt.Errorf("access key not found")
+		}
+		return json.Unmarshal(data, &key)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// List returns every stored key.
+func (s *BoltStore) List() ([]*Key, error) {
+	var keys []*Key
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(keysBucket).ForEach(func(_, data []byte) error {
+			var key Key
+			if err := json.Unmarshal(data, &key); err != nil {
+				return err
+			}
+			keys = append(keys, &key)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list access keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Delete removes the key with the given ID.
+func (s *BoltStore) Delete(id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(keysBucket)
+		c := b.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var key Key
+			if err := json.Unmarshal(v, &key); err != nil {
+				return err
+			}
+			if key.ID == id {
+				return b.Delete(k)
+			}
+		}
+		return fmt.Errorf("access key not found")
+	})
+}
+
+// Close closes the underlying database file.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,31 @@
+package accesskey
+
+import "testing"
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	sig := Sign("secret", "GET", "/api/images/foo.png", "w=100", "20260101T000000Z", HashBody(nil))
+	if !Verify("secret", "GET", "/api/images/foo.png", "w=100", "20260101T000000Z", HashBody(nil), sig) {
+		t.Fatal("Verify rejected a signature Sign just produced")
+	}
+}
+
+func TestVerifyRejectsWrongQuery(t *testing.T) {
+	sig := Sign("secret", "GET", "/api/images/foo.png", "w=100", "20260101T000000Z", HashBody(nil))
+	if Verify("secret", "GET", "/api/images/foo.png", "w=200", "20260101T000000Z", HashBody(nil), sig) {
+		t.Fatal("Verify accepted a signature replayed with a different query string")
+	}
+}
+
+func TestVerifyRejectsWrongSecret(t *testing.T) {
+	sig := Sign("secret", "GET", "/api/images/foo.png", "", "20260101T000000Z", HashBody(nil))
+	if Verify("other", "GET", "/api/images/foo.png", "", "20260101T000000Z", HashBody(nil), sig) {
+		t.Fatal("Verify accepted a signature under the wrong secret key")
+	}
+}
+
+func TestVerifyRejectsTamperedBody(t *testing.T) {
+	sig := Sign("secret", "POST", "/api/images/upload", "", "20260101T000000Z", HashBody([]byte("original")))
+	if Verify("secret", "POST", "/api/images/upload", "", "20260101T000000Z", HashBody([]byte("tampered")), sig) {
+		t.Fatal("Verify accepted a signature after the body hash changed")
+	}
+}
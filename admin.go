@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"go_server/accesskey"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CreateKeyRequest is the body of POST /admin/keys
+type CreateKeyRequest struct {
+	Prefix         string   `json:"prefix"`
+	Operations     []string `json:"operations"`
+	ExpiresInHours int      `json:"expiresInHours,omitempty"`
+}
+
+// createAccessKey issues a new access key/secret key pair scoped to a
+// prefix and a set of operations.
+func createAccessKey(c echo.Context) error {
+	var req CreateKeyRequest
+	if err := c.Bind(&req); err != nil || req.Prefix == "" || len(req.Operations) == 0 {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "prefix and at least one operation are required",
+		})
+	}
+
+	operations := make([]accesskey.Operation, 0, len(req.Operations))
+	for _, op := range req.Operations {
+		operations = append(operations, accesskey.Operation(op))
+	}
+
+	accessKeyID, secretKey, err := accesskey.GenerateKeyPair()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: fmt.Sprintf("Failed to generate access key: %v", err),
+		})
+	}
+
+	key := &accesskey.Key{
+		ID:         accessKeyID,
+		AccessKey:  accessKeyID,
+		SecretKey:  secretKey,
+		Prefix:     req.Prefix,
+		Operations: operations,
+		CreatedAt:  time.Now(),
+	}
+	if req.ExpiresInHours > 0 {
+		key.ExpiresAt = time.Now().Add(time.Duration(req.ExpiresInHours) * time.Hour)
+	}
+
+	if err := keyStore.Create(key); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: fmt.Sprintf("Failed to store access key: %v", err),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, key)
+}
+
+// listAccessKeys returns every access key, with secret keys redacted.
+func listAccessKeys(c echo.Context) error {
+	keys, err := keyStore.List()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: fmt.Sprintf("Failed to list access keys: %v", err),
+		})
+	}
+
+	redacted := make([]accesskey.Key, len(keys))
+	for i, key := range keys {
+		redacted[i] = *key
+		redacted[i].SecretKey = ""
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"keys": redacted,
+	})
+}
+
+// deleteAccessKey revokes an access key by ID.
+func deleteAccessKey(c echo.Context) error {
+	id := c.Param("id")
+	if err := keyStore.Delete(id); err != nil {
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Access key not found",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Access key deleted",
+	})
+}
@@ -0,0 +1,172 @@
+// Package imageproc generates thumbnails and on-demand variants of
+// uploaded images, and pulls poster frames out of uploaded video. It
+// shells out to ffmpeg rather than pulling in a Go image-codec dependency
+// per format, mirroring the server-side thumbnailing approach used by
+// chat/file servers.
+package imageproc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultThumbnailSizes are the widths generated for every uploaded image.
+var DefaultThumbnailSizes = []int{128, 512, 1024}
+
+// IsImage reports whether ext (as returned by filepath.Ext) is an image
+// format this package knows how to process.
+func IsImage(ext string) bool {
+	switch strings.ToLower(ext) {
+	case ".jpg", ".jpeg", ".png", ".gif", ".webp":
+		return true
+	}
+	return false
+}
+
+// IsVideo reports whether ext is a video format a poster frame can be
+// pulled from.
+func IsVideo(ext string) bool {
+	switch strings.ToLower(ext) {
+	case ".mp4", ".webm":
+		return true
+	}
+	return false
+}
+
+// Dimensions returns the pixel dimensions of an encoded image.
+func Dimensions(data []byte) (width, height int, err error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read image dimensions: %w", err)
+	}
+	return cfg.Width, cfg.Height, nil
+}
+
+// ThumbKey returns the sibling key for a thumbnail of the given width,
+// e.g. "images/123_photo.jpg" -> "images/123_photo.thumb_512.webp".
+func ThumbKey(key string, width int, format string) string {
+	ext := filepath.Ext(key)
+	base := strings.TrimSuffix(key, ext)
+	return fmt.Sprintf("%s.thumb_%d.%s", base, width, format)
+}
+
+// PosterKey returns the sibling key a video's poster frame is stored under,
+// e.g. "images/123_clip.mp4" -> "images/123_clip.poster.jpg".
+func PosterKey(key string) string {
+	ext := filepath.Ext(key)
+	base := strings.TrimSuffix(key, ext)
+	return base + ".poster.jpg"
+}
+
+// VariantKey returns the cache key a runtime-requested variant is stored
+// under, keyed by a hash of the original key and the requested parameters.
+func VariantKey(hash string) string {
+	return "processed/" + hash
+}
+
+// ContentType returns the MIME type for one of the formats this package
+// can produce (jpeg, png, webp).
+func ContentType(format string) string {
+	switch strings.ToLower(format) {
+	case "png":
+		return "image/png"
+	case "webp":
+		return "image/webp"
+	default:
+		return "image/jpeg"
+	}
+}
+
+// Resize shells out to ffmpeg to resize (and, by re-encoding, strip EXIF
+// from) an image. width or height may be 0 to preserve aspect ratio.
+func Resize(ctx context.Context, data []byte, width, height int, format string, quality int) ([]byte, error) {
+	scale := fmt.Sprintf("scale=%s:%s", dimArg(width), dimArg(height))
+	args := []string{"-y", "-i", "pipe:0", "-vf", scale, "-f", "image2pipe", "-vcodec", codecFor(format)}
+	if quality > 0 {
+		args = append(args, "-q:v", fmt.Sprintf("%d", qualityToFFmpegScale(quality)))
+	}
+	args = append(args, "-")
+
+	out, err := runFFmpeg(ctx, data, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resize image: %w", err)
+	}
+	return out, nil
+}
+
+// PosterFrame extracts the first frame of a video as a JPEG, for use as a
+// thumbnail. inputExt (e.g. ".mp4") is needed because ffmpeg identifies
+// container format from the file extension, not from stdin.
+func PosterFrame(ctx context.Context, data []byte, inputExt string) ([]byte, error) {
+	in, err := os.CreateTemp("", "poster-*"+inputExt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(in.Name())
+
+	if _, err := in.Write(data); err != nil {
+		in.Close()
+		return nil, fmt.Errorf("failed to write temp file: %w", err)
+	}
+	in.Close()
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", "-y", "-i", in.Name(), "-frames:v", "1", "-f", "image2pipe", "-vcodec", "mjpeg", "-")
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("ffmpeg poster-frame extraction failed: %w: %s", err, stderr.String())
+	}
+	return out.Bytes(), nil
+}
+
+func runFFmpeg(ctx context.Context, data []byte, args []string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	cmd.Stdin = bytes.NewReader(data)
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, stderr.String())
+	}
+	return out.Bytes(), nil
+}
+
+func dimArg(v int) string {
+	if v <= 0 {
+		return "-1"
+	}
+	return fmt.Sprintf("%d", v)
+}
+
+func codecFor(format string) string {
+	switch strings.ToLower(format) {
+	case "png":
+		return "png"
+	case "webp":
+		return "libwebp"
+	default:
+		return "mjpeg"
+	}
+}
+
+// qualityToFFmpegScale maps a 1-100 "bigger is nicer" quality knob onto
+// ffmpeg's inverted 2-31 -q:v scale.
+func qualityToFFmpegScale(quality int) int {
+	if quality < 1 {
+		quality = 1
+	}
+	if quality > 100 {
+		quality = 100
+	}
+	return 31 - (quality*29)/100
+}
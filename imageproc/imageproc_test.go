@@ -0,0 +1,42 @@
+package imageproc
+
+import "testing"
+
+func TestThumbKey(t *testing.T) {
+	got := ThumbKey("images/123_photo.jpg", 512, "webp")
+	want := "images/123_photo.thumb_512.webp"
+	if got != want {
+		t.Fatalf("ThumbKey() = %q, want %q", got, want)
+	}
+}
+
+func TestPosterKey(t *testing.T) {
+	got := PosterKey("images/123_clip.mp4")
+	want := "images/123_clip.poster.jpg"
+	if got != want {
+		t.Fatalf("PosterKey() = %q, want %q", got, want)
+	}
+}
+
+func TestVariantKey(t *testing.T) {
+	got := VariantKey("abc123")
+	want := "processed/abc123"
+	if got != want {
+		t.Fatalf("VariantKey() = %q, want %q", got, want)
+	}
+}
+
+func TestIsImageIsVideo(t *testing.T) {
+	if !IsImage(".JPG") {
+		t.Error("IsImage(\".JPG\") = false, want true")
+	}
+	if IsImage(".mp4") {
+		t.Error("IsImage(\".mp4\") = true, want false")
+	}
+	if !IsVideo(".webm") {
+		t.Error("IsVideo(\".webm\") = false, want true")
+	}
+	if IsVideo(".png") {
+		t.Error("IsVideo(\".png\") = true, want false")
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"go_server/accesskey"
+	"go_server/imageproc"
+	"go_server/storage"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// generateDerivatives creates thumbnails for an uploaded image, or a
+// poster-frame thumbnail for an uploaded video. It logs rather than fails
+// the upload on error, since derivatives are a nice-to-have.
+func generateDerivatives(ctx context.Context, key, ext string, data []byte) {
+	switch {
+	case imageproc.IsImage(ext):
+		for _, width := range imageproc.DefaultThumbnailSizes {
+			thumb, err := imageproc.Resize(ctx, data, width, 0, "webp", 80)
+			if err != nil {
+				fmt.Printf("Warning: failed to generate %dpx thumbnail for %s: %v\n", width, key, err)
+				continue
+			}
+			thumbKey := imageproc.ThumbKey(key, width, "webp")
+			if err := store.Put(ctx, thumbKey, bytes.NewReader(thumb), imageproc.ContentType("webp")); err != nil {
+				fmt.Printf("Warning: failed to store thumbnail %s: %v\n", thumbKey, err)
+			}
+		}
+
+	case imageproc.IsVideo(ext):
+		poster, err := imageproc.PosterFrame(ctx, data, ext)
+		if err != nil {
+			fmt.Printf("Warning: failed to extract poster frame for %s: %v\n", key, err)
+			return
+		}
+		posterKey := imageproc.PosterKey(key)
+		if err := store.Put(ctx, posterKey, bytes.NewReader(poster), imageproc.ContentType("jpeg")); err != nil {
+			fmt.Printf("Warning: failed to store poster frame %s: %v\n", posterKey, err)
+		}
+	}
+}
+
+// getImageVariant serves a resized/reformatted version of an uploaded
+// image, generating and caching it under a "processed/<hash>" key on miss.
+func getImageVariant(c echo.Context) error {
+	if store == nil {
+		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error: "Storage service not configured",
+		})
+	}
+
+	caller := callerKey(c)
+	if caller == nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+	}
+	if !caller.Allows(accesskey.OpRead) {
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: "Access key is not scoped for read",
+		})
+	}
+
+	key := c.Param("key")
+	if !storage.ValidKey(key) {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid image key",
+		})
+	}
+	fullKey := caller.Prefix + key
+
+	width, height, format, quality, _ := variantParams(c)
+
+	variant, notFound, err := getOrGenerateVariant(c.Request().Context(), fullKey, width, height, format, quality)
+	if notFound {
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Image not found",
+		})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	return serveVariant(c, variant, format)
+}
+
+// variantParams reads the w/h/fmt/q query params used by both
+// getImageVariant and downloadImage's transparent-variant path. requested
+// reports whether any of them were actually given, so downloadImage can
+// tell a plain download from a variant request.
+func variantParams(c echo.Context) (width, height int, format string, quality int, requested bool) {
+	wStr := c.QueryParam("w")
+	hStr := c.QueryParam("h")
+	format = c.QueryParam("fmt")
+	qStr := c.QueryParam("q")
+	requested = wStr != "" || hStr != "" || format != "" || qStr != ""
+
+	width, _ = strconv.Atoi(wStr)
+	height, _ = strconv.Atoi(hStr)
+	if format == "" {
+		format = "webp"
+	}
+	if q, err := strconv.Atoi(qStr); err == nil && q > 0 {
+		quality = q
+	} else {
+		quality = 80
+	}
+	return width, height, format, quality, requested
+}
+
+// maybeServeVariant serves a generated-or-cached variant of fullKey when
+// the request carries w/h/fmt/q query params, so downloadImage can serve
+// variants transparently instead of requiring a separate /variant call.
+// handled reports whether it wrote a response at all.
+func maybeServeVariant(c echo.Context, fullKey string) (handled bool, err error) {
+	width, height, format, quality, requested := variantParams(c)
+	if !requested {
+		return false, nil
+	}
+
+	variant, notFound, err := getOrGenerateVariant(c.Request().Context(), fullKey, width, height, format, quality)
+	if notFound {
+		return true, c.JSON(http.StatusNotFound, ErrorResponse{Error: "Image not found"})
+	}
+	if err != nil {
+		return true, c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+	return true, serveVariant(c, variant, format)
+}
+
+// getOrGenerateVariant returns the bytes of a cached variant of fullKey,
+// generating and caching it under a "processed/<hash>" key on miss.
+// notFound distinguishes a missing original (404) from a resize failure
+// (500).
+func getOrGenerateVariant(ctx context.Context, fullKey string, width, height int, format string, quality int) (variant []byte, notFound bool, err error) {
+	processedKey := imageproc.VariantKey(variantHash(fullKey, width, height, format, quality))
+
+	if cached, err := store.Get(ctx, processedKey); err == nil {
+		return cached, false, nil
+	}
+
+	original, err := store.Get(ctx, fullKey)
+	if err != nil {
+		return nil, true, err
+	}
+
+	variant, err = imageproc.Resize(ctx, original, width, height, format, quality)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to generate variant: %w", err)
+	}
+
+	if err := store.Put(ctx, processedKey, bytes.NewReader(variant), imageproc.ContentType(format)); err != nil {
+		fmt.Printf("Warning: failed to cache image variant %s: %v\n", processedKey, err)
+	}
+
+	return variant, false, nil
+}
+
+func serveVariant(c echo.Context, data []byte, format string) error {
+	sum := md5.Sum(data)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+
+	c.Response().Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	c.Response().Header().Set("ETag", etag)
+	return c.Blob(http.StatusOK, imageproc.ContentType(format), data)
+}
+
+func variantHash(key string, width, height int, format string, quality int) string {
+	sum := md5.Sum([]byte(fmt.Sprintf("%s|%d|%d|%s|%d", key, width, height, format, quality)))
+	return hex.EncodeToString(sum[:])
+}
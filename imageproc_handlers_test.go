@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestVariantHashStableForSameParams(t *testing.T) {
+	a := variantHash("images/123_photo.jpg", 512, 0, "webp", 80)
+	b := variantHash("images/123_photo.jpg", 512, 0, "webp", 80)
+	if a != b {
+		t.Fatalf("variantHash is not stable: %q != %q", a, b)
+	}
+}
+
+func TestVariantHashDiffersByParam(t *testing.T) {
+	base := variantHash("images/123_photo.jpg", 512, 0, "webp", 80)
+
+	variants := []string{
+		variantHash("images/other_photo.jpg", 512, 0, "webp", 80),
+		variantHash("images/123_photo.jpg", 1024, 0, "webp", 80),
+		variantHash("images/123_photo.jpg", 512, 256, "webp", 80),
+		variantHash("images/123_photo.jpg", 512, 0, "png", 80),
+		variantHash("images/123_photo.jpg", 512, 0, "webp", 60),
+	}
+	for _, v := range variants {
+		if v == base {
+			t.Fatalf("variantHash did not change when a parameter changed: %q", v)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"go_server/accesskey"
+	"go_server/storage"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ImageListItem is one entry returned by listImages.
+type ImageListItem struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"lastModified"`
+	ETag         string    `json:"etag"`
+	ContentType  string    `json:"contentType"`
+}
+
+// contentTypeForExt guesses a Content-Type from a file extension for
+// backends/keys that don't carry one of their own.
+func contentTypeForExt(ext string) string {
+	switch strings.ToLower(ext) {
+	case ".jpg", ".jpeg":
+		return "image/jpeg"
+	case ".png":
+		return "image/png"
+	case ".gif":
+		return "image/gif"
+	case ".webp":
+		return "image/webp"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// notModified reports whether the request's conditional headers
+// (If-None-Match / If-Modified-Since) are already satisfied by info.
+func notModified(c echo.Context, info storage.ObjectInfo) bool {
+	if info.ETag != "" {
+		if inm := c.Request().Header.Get("If-None-Match"); inm != "" && inm == info.ETag {
+			return true
+		}
+	}
+	if !info.LastModified.IsZero() {
+		if ims := c.Request().Header.Get("If-Modified-Since"); ims != "" {
+			if t, err := time.Parse(http.TimeFormat, ims); err == nil && !info.LastModified.After(t) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// setCacheHeaders writes ETag/Last-Modified so the client can revalidate
+// on the next request.
+func setCacheHeaders(c echo.Context, info storage.ObjectInfo) {
+	if info.ETag != "" {
+		c.Response().Header().Set("ETag", info.ETag)
+	}
+	if !info.LastModified.IsZero() {
+		c.Response().Header().Set("Last-Modified", info.LastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// listImages handles paginated, filterable listing of images
+func listImages(c echo.Context) error {
+	if store == nil {
+		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error: "Storage service not configured",
+		})
+	}
+
+	caller := callerKey(c)
+	if caller == nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+	}
+	if !caller.Allows(accesskey.OpList) {
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: "Access key is not scoped for list",
+		})
+	}
+
+	prefix := c.QueryParam("prefix")
+	if prefix == "" {
+		prefix = "images/"
+	}
+	if !storage.ValidKey(prefix) {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid prefix",
+		})
+	}
+
+	limit := int32(50)
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil && l > 0 {
+		limit = int32(l)
+	}
+
+	var since, until time.Time
+	if s := c.QueryParam("since"); s != "" {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "since must be an RFC3339 timestamp",
+			})
+		}
+		since = t
+	}
+	if u := c.QueryParam("until"); u != "" {
+		t, err := time.Parse(time.RFC3339, u)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "until must be an RFC3339 timestamp",
+			})
+		}
+		until = t
+	}
+	ext := strings.ToLower(c.QueryParam("ext"))
+
+	ctx := c.Request().Context()
+	items, nextCursor, truncated, err := store.ListPage(ctx, caller.Prefix+prefix, limit, c.QueryParam("cursor"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: fmt.Sprintf("Failed to list images: %v", err),
+		})
+	}
+
+	filtered := make([]ImageListItem, 0, len(items))
+	for _, item := range items {
+		if !since.IsZero() && item.LastModified.Before(since) {
+			continue
+		}
+		if !until.IsZero() && item.LastModified.After(until) {
+			continue
+		}
+		itemExt := strings.ToLower(filepath.Ext(item.Key))
+		if ext != "" && itemExt != ext {
+			continue
+		}
+
+		contentType := item.ContentType
+		if contentType == "" {
+			contentType = contentTypeForExt(itemExt)
+		}
+
+		filtered = append(filtered, ImageListItem{
+			// Strip the caller's prefix back off, since callers never see
+			// or send their own prefix.
+			Key:          strings.TrimPrefix(item.Key, caller.Prefix),
+			Size:         item.Size,
+			LastModified: item.LastModified,
+			ETag:         item.ETag,
+			ContentType:  contentType,
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"items":      filtered,
+		"nextCursor": nextCursor,
+		"truncated":  truncated,
+	})
+}
+
+// headImage returns an image's metadata (size, ETag, LastModified) without
+// its body.
+func headImage(c echo.Context) error {
+	if store == nil {
+		return c.NoContent(http.StatusServiceUnavailable)
+	}
+
+	caller := callerKey(c)
+	if caller == nil {
+		return c.NoContent(http.StatusUnauthorized)
+	}
+	if !caller.Allows(accesskey.OpRead) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
+	key := c.Param("key")
+	if !storage.ValidKey(key) {
+		return c.NoContent(http.StatusBadRequest)
+	}
+	info, err := store.Stat(c.Request().Context(), caller.Prefix+key)
+	if err != nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	setCacheHeaders(c, info)
+	c.Response().Header().Set("Content-Length", strconv.FormatInt(info.Size, 10))
+
+	contentType := info.ContentType
+	if contentType == "" {
+		contentType = contentTypeForExt(filepath.Ext(key))
+	}
+	c.Response().Header().Set("Content-Type", contentType)
+
+	return c.NoContent(http.StatusOK)
+}
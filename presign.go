@@ -0,0 +1,92 @@
+package main
+
+import (
+	"go_server/accesskey"
+	"go_server/storage"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PresignUploadRequest is the body of POST /api/images/presign-upload
+type PresignUploadRequest struct {
+	Prefix              string   `json:"prefix"`
+	MaxSizeBytes        int64    `json:"maxSizeBytes"`
+	AllowedContentTypes []string `json:"allowedContentTypes"`
+	ExpireMinutes       int64    `json:"expireMinutes"`
+}
+
+// PresignUploadResponse is what the browser needs to POST a file straight
+// to the storage backend.
+type PresignUploadResponse struct {
+	URL    string            `json:"url"`
+	Fields map[string]string `json:"fields"`
+}
+
+// presignUpload issues a presigned POST policy so a browser can upload
+// directly to the storage backend without the file passing through this
+// server. The caller's access-key prefix is always prepended server-side,
+// so a policy can never be scoped outside the caller's own namespace.
+func presignUpload(c echo.Context) error {
+	if store == nil {
+		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error: "Storage service not configured",
+		})
+	}
+	ppb, ok := store.(storage.PostPolicyBackend)
+	if !ok {
+		return c.JSON(http.StatusNotImplemented, ErrorResponse{
+			Error: "Configured storage backend does not support presigned POST uploads",
+		})
+	}
+
+	caller := callerKey(c)
+	if caller == nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+	}
+	if !caller.Allows(accesskey.OpWrite) {
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: "Access key is not scoped for write",
+		})
+	}
+
+	var req PresignUploadRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+	}
+	if !storage.ValidKey(req.Prefix) {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid prefix",
+		})
+	}
+	if len(req.AllowedContentTypes) > 1 {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "A presigned POST can only enforce at most one allowed content type",
+		})
+	}
+	expireMinutes := req.ExpireMinutes
+	if expireMinutes <= 0 {
+		expireMinutes = 15
+	}
+
+	keyPrefix := caller.Prefix + "images/" + req.Prefix
+
+	post, err := ppb.PresignPost(c.Request().Context(), keyPrefix, storage.PostPolicyConstraints{
+		MaxSizeBytes:        req.MaxSizeBytes,
+		AllowedContentTypes: req.AllowedContentTypes,
+	}, expireMinutes)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to generate presigned POST",
+		})
+	}
+
+	return c.JSON(http.StatusOK, PresignUploadResponse{
+		URL:    post.URL,
+		Fields: post.Fields,
+	})
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"go_server/accesskey"
 	"go_server/storage"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -16,25 +19,37 @@ import (
 	_ "github.com/joho/godotenv/autoload"
 )
 
-var r2Client *storage.R2Client
+var store storage.Backend
+var keyStore accesskey.Store
 
 func init() {
-	// Initialize R2 client
-	accountID := os.Getenv("R2_ACCOUNT_ID")
-	accessKeyID := os.Getenv("R2_ACCESS_KEY_ID")
-	secretAccessKey := os.Getenv("R2_SECRET_ACCESS_KEY")
-	bucketName := os.Getenv("R2_BUCKET_NAME")
-
-	if accountID == "" || accessKeyID == "" || secretAccessKey == "" || bucketName == "" {
-		fmt.Println("Warning: R2 credentials not configured. Please set environment variables.")
+	// Initialize the configured storage backend (STORAGE_BACKEND=r2|b2|azure|gcs|fs)
+	backend, err := storage.NewBackendFromEnv()
+	if err != nil {
+		fmt.Printf("Failed to initialize storage backend: %v\n", err)
+		return
+	}
+	if backend == nil {
+		fmt.Println("Warning: storage backend not configured. Please set its environment variables.")
 		return
 	}
+	store = backend
+}
 
-	var err error
-	r2Client, err = storage.NewR2Client(accountID, accessKeyID, secretAccessKey, bucketName)
+func init() {
+	// Initialize the access key store (per-user credentials, replacing the
+	// old single Basic Auth login)
+	dbPath := os.Getenv("ACCESS_KEY_DB_PATH")
+	if dbPath == "" {
+		dbPath = "./data/accesskeys.db"
+	}
+
+	boltStore, err := accesskey.NewBoltStore(dbPath)
 	if err != nil {
-		fmt.Printf("Failed to initialize R2 client: %v\n", err)
+		fmt.Printf("Failed to initialize access key store: %v\n", err)
+		return
 	}
+	keyStore = boltStore
 }
 
 // UploadResponse represents the response after successful upload
@@ -51,12 +66,24 @@ type ErrorResponse struct {
 
 // uploadImage handles image upload
 func uploadImage(c echo.Context) error {
-	if r2Client == nil {
+	if store == nil {
 		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
 			Error: "Storage service not configured",
 		})
 	}
 
+	caller := callerKey(c)
+	if caller == nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+	}
+	if !caller.Allows(accesskey.OpWrite) {
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: "Access key is not scoped for write",
+		})
+	}
+
 	// Parse multipart form
 	file, err := c.FormFile("image")
 	if err != nil {
@@ -72,30 +99,65 @@ func uploadImage(c echo.Context) error {
 		".png":  true,
 		".gif":  true,
 		".webp": true,
+		".mp4":  true,
+		".webm": true,
 	}
 
 	ext := strings.ToLower(filepath.Ext(file.Filename))
 	if !allowedTypes[ext] {
 		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "Invalid file type. Only images are allowed",
+			Error: "Invalid file type. Only images and video are allowed",
 		})
 	}
 
-	// Generate unique key for the file
+	if !storage.ValidKey(file.Filename) {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid filename",
+		})
+	}
+
+	// Generate unique key for the file, scoped under the caller's prefix
 	timestamp := time.Now().Unix()
 	key := fmt.Sprintf("images/%d_%s", timestamp, file.Filename)
+	fullKey := caller.Prefix + key
 
-	// Upload to R2
+	// Upload to the configured backend
 	ctx := context.Background()
-	err = r2Client.UploadMultipartFile(ctx, key, file)
+	src, err := file.Open()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: fmt.Sprintf("Failed to open uploaded file: %v", err),
+		})
+	}
+	defer src.Close()
+
+	contentType := file.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	// Thumbnail/poster generation needs the full bytes, so tee them into buf
+	// while the backend reads directly off src instead of an io.ReadAll'd
+	// buffer — large uploads never sit fully buffered before the upload to
+	// storage even starts.
+	var buf bytes.Buffer
+	tee := io.TeeReader(src, &buf)
+
+	if sb, ok := store.(storage.StreamingBackend); ok {
+		err = sb.UploadStream(ctx, fullKey, tee, contentType, storage.UploadStreamOptions{})
+	} else {
+		err = store.Put(ctx, fullKey, tee, contentType)
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error: fmt.Sprintf("Failed to upload image: %v", err),
 		})
 	}
 
+	generateDerivatives(ctx, fullKey, ext, buf.Bytes())
+
 	// Generate presigned URL (optional - for direct access)
-	url, _ := r2Client.GetPresignedURL(ctx, key, 60) // 60 minutes expiry
+	url, _ := store.Presign(ctx, fullKey, 60) // 60 minutes expiry
 
 	return c.JSON(http.StatusOK, UploadResponse{
 		Key:     key,
@@ -106,61 +168,99 @@ func uploadImage(c echo.Context) error {
 
 // downloadImage handles image download
 func downloadImage(c echo.Context) error {
-	if r2Client == nil {
+	if store == nil {
 		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
 			Error: "Storage service not configured",
 		})
 	}
 
+	caller := callerKey(c)
+	if caller == nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+	}
+	if !caller.Allows(accesskey.OpRead) {
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: "Access key is not scoped for read",
+		})
+	}
+
 	key := c.Param("key")
 	if key == "" {
 		return c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error: "Image key is required",
 		})
 	}
+	if !storage.ValidKey(key) {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid image key",
+		})
+	}
+	fullKey := caller.Prefix + key
 
-	ctx := context.Background()
-	data, err := r2Client.DownloadFile(ctx, key)
+	ctx := c.Request().Context()
+
+	// Serve a resized/reformatted variant transparently when w/h/fmt/q query
+	// params are present, the same way getImageVariant does, instead of
+	// requiring a separate round trip to the /variant endpoint.
+	if handled, err := maybeServeVariant(c, fullKey); handled {
+		return err
+	}
+
+	// Revalidate against the object's ETag/LastModified before paying for a
+	// full download, so browsers/CDNs can cheaply confirm their cache is fresh.
+	if info, err := store.Stat(ctx, fullKey); err == nil {
+		if notModified(c, info) {
+			return c.NoContent(http.StatusNotModified)
+		}
+		setCacheHeaders(c, info)
+	}
+
+	data, err := store.Get(ctx, fullKey)
 	if err != nil {
 		return c.JSON(http.StatusNotFound, ErrorResponse{
 			Error: "Image not found",
 		})
 	}
 
-	// Determine content type based on extension
-	contentType := "application/octet-stream"
-	ext := strings.ToLower(filepath.Ext(key))
-	switch ext {
-	case ".jpg", ".jpeg":
-		contentType = "image/jpeg"
-	case ".png":
-		contentType = "image/png"
-	case ".gif":
-		contentType = "image/gif"
-	case ".webp":
-		contentType = "image/webp"
-	}
-
-	return c.Blob(http.StatusOK, contentType, data)
+	return c.Blob(http.StatusOK, contentTypeForExt(filepath.Ext(key)), data)
 }
 
 // deleteImage handles image deletion
 func deleteImage(c echo.Context) error {
-	if r2Client == nil {
+	if store == nil {
 		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
 			Error: "Storage service not configured",
 		})
 	}
 
+	caller := callerKey(c)
+	if caller == nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+	}
+	if !caller.Allows(accesskey.OpDelete) {
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: "Access key is not scoped for delete",
+		})
+	}
+
 	key := c.Param("key")
 	if key == "" {
 		return c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error: "Image key is required",
 		})
 	}
+	if !storage.ValidKey(key) {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid image key",
+		})
+	}
 
 	ctx := context.Background()
-	err := r2Client.DeleteFile(ctx, key)
+	err := store.Delete(ctx, caller.Prefix+key)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error: fmt.Sprintf("Failed to delete image: %v", err),
@@ -172,28 +272,6 @@ func deleteImage(c echo.Context) error {
 	})
 }
 
-// listImages handles listing all images
-func listImages(c echo.Context) error {
-	if r2Client == nil {
-		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
-			Error: "Storage service not configured",
-		})
-	}
-
-	ctx := context.Background()
-	files, err := r2Client.ListFiles(ctx, "images/")
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: fmt.Sprintf("Failed to list images: %v", err),
-		})
-	}
-
-	return c.JSON(http.StatusOK, map[string]interface{}{
-		"images": files,
-		"count":  len(files),
-	})
-}
-
 func basicAuthMiddleware(username, password string) echo.MiddlewareFunc {
 	return middleware.BasicAuth(func(u, p string, ctx echo.Context) (bool, error) {
 		if u == username && p == password {
@@ -210,13 +288,6 @@ func main() {
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORS())
-	authUsername := os.Getenv("AUTH_USERNAME")
-	authPassword := os.Getenv("AUTH_PASSWORD")
-	if authUsername == "" || authPassword == "" {
-		fmt.Println("Warning: Basic Auth credentials not set. Please configure AUTH_USERNAME and AUTH_PASSWORD.")
-	} else {
-		e.Use(basicAuthMiddleware(authUsername, authPassword))
-	}
 
 	// Routes
 	e.GET("/", func(c echo.Context) error {
@@ -226,12 +297,38 @@ func main() {
 		})
 	})
 
-	// Image endpoints
+	// Image endpoints, authenticated per-caller via access keys
 	api := e.Group("/api")
+	if keyStore != nil {
+		api.Use(accessKeyAuthMiddleware(keyStore))
+	}
 	api.POST("/images/upload", uploadImage)
 	api.GET("/images/:key", downloadImage)
+	api.HEAD("/images/:key", headImage)
 	api.DELETE("/images/:key", deleteImage)
 	api.GET("/images", listImages)
+	api.GET("/images/:key/variant", getImageVariant)
+	api.POST("/images/presign-upload", presignUpload)
+
+	// Resumable multipart upload endpoints
+	api.POST("/uploads/init", initUpload)
+	api.GET("/uploads/:id", getUpload)
+	api.POST("/uploads/:id/part", uploadPart)
+	api.POST("/uploads/:id/complete", completeUpload)
+	api.POST("/uploads/:id/abort", abortUpload)
+
+	// Admin endpoints for managing access keys, protected by a single
+	// root-admin credential rather than per-user access keys
+	adminUsername := os.Getenv("ADMIN_USERNAME")
+	adminPassword := os.Getenv("ADMIN_PASSWORD")
+	if adminUsername == "" || adminPassword == "" {
+		fmt.Println("Warning: Admin credentials not set. Please configure ADMIN_USERNAME and ADMIN_PASSWORD.")
+	} else {
+		admin := e.Group("/admin", basicAuthMiddleware(adminUsername, adminPassword))
+		admin.POST("/keys", createAccessKey)
+		admin.GET("/keys", listAccessKeys)
+		admin.DELETE("/keys/:id", deleteAccessKey)
+	}
 
 	// Get port from environment variable or default to 8080
 	port := os.Getenv("PORT")
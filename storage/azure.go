@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
+)
+
+// AzureBackend is the Backend implementation for Azure Blob Storage.
+type AzureBackend struct {
+	client        *azblob.Client
+	credential    *service.SharedKeyCredential
+	containerName string
+}
+
+// NewAzureBackend creates a new Azure Blob Storage backend for the given container
+func NewAzureBackend(accountName, accountKey, containerName string) (*AzureBackend, error) {
+	credential, err := service.NewSharedKeyCredential(accountName, accountKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create azure credential: %w", err)
+	}
+
+	serviceURL := fmt.Sprintf("https://%s.blob.core.windows.net/", accountName)
+	client, err := azblob.NewClientWithSharedKeyCredential(serviceURL, credential, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create azure client: %w", err)
+	}
+
+	return &AzureBackend{
+		client:        client,
+		credential:    credential,
+		containerName: containerName,
+	}, nil
+}
+
+// Put uploads a blob to the container
+func (a *AzureBackend) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	headers := blob.HTTPHeaders{BlobContentType: &contentType}
+	_, err = a.client.UploadBuffer(ctx, a.containerName, key, data, &azblob.UploadBufferOptions{
+		HTTPHeaders: &headers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+	return nil
+}
+
+// Get downloads a blob from the container
+func (a *AzureBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := a.client.DownloadStream(ctx, a.containerName, key, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	buf := bytes.NewBuffer(nil)
+	if _, err := io.Copy(buf, resp.Body); err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Delete deletes a blob from the container
+func (a *AzureBackend) Delete(ctx context.Context, key string) error {
+	_, err := a.client.DeleteBlob(ctx, a.containerName, key, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// List lists blobs in the container with optional prefix
+func (a *AzureBackend) List(ctx context.Context, prefix string) ([]string, error) {
+	var files []string
+
+	pager := a.client.NewListBlobsFlatPager(a.containerName, &container.ListBlobsFlatOptions{
+		Prefix: &prefix,
+	})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files: %w", err)
+		}
+		for _, blob := range page.Segment.BlobItems {
+			files = append(files, *blob.Name)
+		}
+	}
+
+	return files, nil
+}
+
+// ListPage lists blobs a page at a time, resuming from cursor (an Azure
+// listing marker) when given.
+func (a *AzureBackend) ListPage(ctx context.Context, prefix string, limit int32, cursor string) ([]ObjectInfo, string, bool, error) {
+	opts := &container.ListBlobsFlatOptions{
+		Prefix:     &prefix,
+		MaxResults: &limit,
+		Include:    container.ListBlobsInclude{},
+	}
+	if cursor != "" {
+		opts.Marker = &cursor
+	}
+
+	pager := a.client.NewListBlobsFlatPager(a.containerName, opts)
+	if !pager.More() {
+		return nil, "", false, nil
+	}
+
+	page, err := pager.NextPage(ctx)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	items := make([]ObjectInfo, 0, len(page.Segment.BlobItems))
+	for _, blob := range page.Segment.BlobItems {
+		info := ObjectInfo{Key: *blob.Name}
+		if blob.Properties != nil {
+			if blob.Properties.ContentLength != nil {
+				info.Size = *blob.Properties.ContentLength
+			}
+			if blob.Properties.LastModified != nil {
+				info.LastModified = *blob.Properties.LastModified
+			}
+			if blob.Properties.ETag != nil {
+				info.ETag = string(*blob.Properties.ETag)
+			}
+			if blob.Properties.ContentType != nil {
+				info.ContentType = *blob.Properties.ContentType
+			}
+		}
+		items = append(items, info)
+	}
+
+	nextCursor := ""
+	truncated := page.NextMarker != nil && *page.NextMarker != ""
+	if truncated {
+		nextCursor = *page.NextMarker
+	}
+
+	return items, nextCursor, truncated, nil
+}
+
+// Stat returns metadata for a single blob without fetching its body
+func (a *AzureBackend) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	props, err := a.client.ServiceClient().NewContainerClient(a.containerName).NewBlobClient(key).GetProperties(ctx, nil)
+	if err != nil {
+		return ObjectInfo{}, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	info := ObjectInfo{Key: key}
+	if props.ContentLength != nil {
+		info.Size = *props.ContentLength
+	}
+	if props.LastModified != nil {
+		info.LastModified = *props.LastModified
+	}
+	if props.ETag != nil {
+		info.ETag = string(*props.ETag)
+	}
+	if props.ContentType != nil {
+		info.ContentType = *props.ContentType
+	}
+	return info, nil
+}
+
+// Presign generates a SAS URL for downloading the blob
+func (a *AzureBackend) Presign(ctx context.Context, key string, expireMinutes int64) (string, error) {
+	blobClient := a.client.ServiceClient().NewContainerClient(a.containerName).NewBlobClient(key)
+
+	permission := sas.BlobPermissions{Read: true}
+	expiry := time.Now().Add(time.Duration(expireMinutes) * time.Minute)
+
+	url, err := blobClient.GetSASURL(permission, expiry, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create SAS URL: %w", err)
+	}
+	return url, nil
+}
@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/kurin/blazer/b2"
+)
+
+// B2Backend is the Backend implementation for Backblaze B2. Unlike plain
+// S3-style PutObject, B2's writer already chunks large uploads internally,
+// which gives callers resumable large-file uploads for free.
+type B2Backend struct {
+	bucket *b2.Bucket
+}
+
+// NewB2Backend creates a new Backblaze B2 backend for the given bucket
+func NewB2Backend(accountID, applicationKey, bucketName string) (*B2Backend, error) {
+	ctx := context.Background()
+
+	client, err := b2.NewClient(ctx, accountID, applicationKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create b2 client: %w", err)
+	}
+
+	bucket, err := client.Bucket(ctx, bucketName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open b2 bucket: %w", err)
+	}
+
+	return &B2Backend{bucket: bucket}, nil
+}
+
+// Put uploads a file to B2
+func (b *B2Backend) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	w := b.bucket.Object(key).NewWriter(ctx).WithAttrs(&b2.Attrs{ContentType: contentType})
+	w.Resume = true
+
+	if _, err := io.Copy(w, body); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+	return nil
+}
+
+// Get downloads a file from B2
+func (b *B2Backend) Get(ctx context.Context, key string) ([]byte, error) {
+	r := b.bucket.Object(key).NewReader(ctx)
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	return data, nil
+}
+
+// Delete deletes a file from B2
+func (b *B2Backend) Delete(ctx context.Context, key string) error {
+	if err := b.bucket.Object(key).Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// List lists files in the bucket with optional prefix
+func (b *B2Backend) List(ctx context.Context, prefix string) ([]string, error) {
+	var files []string
+
+	iter := b.bucket.List(ctx, b2.ListPrefix(prefix))
+	for iter.Next() {
+		files = append(files, iter.Object().Name())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	return files, nil
+}
+
+// ListPage lists objects a page at a time. blazer's List iterator has no
+// serializable resume token of its own (unlike ListPrefix/ListPageSize,
+// there's no ListCursor ListOption), so cursor is a decimal offset into the
+// prefix's (stably ordered) listing, the same synthetic-cursor approach
+// FSBackend uses. B2 exposes no ETag, so ObjectInfo.ETag is left blank.
+func (b *B2Backend) ListPage(ctx context.Context, prefix string, limit int32, cursor string) ([]ObjectInfo, string, bool, error) {
+	offset := 0
+	if cursor != "" {
+		o, err := strconv.Atoi(cursor)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("invalid cursor %q: %w", cursor, err)
+		}
+		offset = o
+	}
+
+	iter := b.bucket.List(ctx, b2.ListPrefix(prefix))
+
+	items := make([]ObjectInfo, 0, limit)
+	index := 0
+	truncated := false
+	for iter.Next() {
+		if index < offset {
+			index++
+			continue
+		}
+		if int32(len(items)) >= limit {
+			truncated = true
+			break
+		}
+
+		obj := iter.Object()
+		attrs, err := obj.Attrs(ctx)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("failed to stat file: %w", err)
+		}
+		items = append(items, ObjectInfo{
+			Key:          obj.Name(),
+			Size:         attrs.Size,
+			LastModified: attrs.UploadTimestamp,
+			ContentType:  attrs.ContentType,
+		})
+		index++
+	}
+	if err := iter.Err(); err != nil && !truncated {
+		return nil, "", false, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	nextCursor := ""
+	if truncated {
+		nextCursor = strconv.Itoa(index)
+	}
+
+	return items, nextCursor, truncated, nil
+}
+
+// Stat returns metadata for a single object without fetching its body. As in
+// ListPage, ETag is left blank.
+func (b *B2Backend) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	attrs, err := b.bucket.Object(key).Attrs(ctx)
+	if err != nil {
+		return ObjectInfo{}, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	return ObjectInfo{
+		Key:          key,
+		Size:         attrs.Size,
+		LastModified: attrs.UploadTimestamp,
+		ContentType:  attrs.ContentType,
+	}, nil
+}
+
+// Presign generates a temporary download URL for the given key
+func (b *B2Backend) Presign(ctx context.Context, key string, expireMinutes int64) (string, error) {
+	u, err := b.bucket.Object(key).AuthURL(ctx, time.Duration(expireMinutes)*time.Minute, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to create authorized URL: %w", err)
+	}
+	return u.String(), nil
+}
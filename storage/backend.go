@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// ObjectInfo describes a single stored object, independent of which backend
+// holds it.
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+	ETag         string
+	ContentType  string
+}
+
+// Backend is the storage-agnostic interface implemented by every object
+// store this server can talk to (R2/S3, B2, Azure Blob, GCS, local
+// filesystem). Handlers depend on this interface rather than any concrete
+// client so the backend can be swapped with STORAGE_BACKEND without
+// touching request-handling code.
+type Backend interface {
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+	List(ctx context.Context, prefix string) ([]string, error)
+	// ListPage lists objects a page at a time, with size/etag/lastModified
+	// metadata included so callers don't need a HeadObject round trip per key.
+	ListPage(ctx context.Context, prefix string, limit int32, cursor string) (items []ObjectInfo, nextCursor string, truncated bool, err error)
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	Presign(ctx context.Context, key string, expireMinutes int64) (string, error)
+}
+
+// StreamingBackend is an optional capability implemented by backends that
+// can upload a reader directly to storage without buffering the whole body
+// in memory first (see R2Client.UploadStream). Handlers should type-assert
+// a Backend to this interface and fall back to Put when the active backend
+// doesn't support it.
+type StreamingBackend interface {
+	UploadStream(ctx context.Context, key string, body io.Reader, contentType string, opts UploadStreamOptions) error
+}
+
+// PostPolicyBackend is an optional capability implemented by backends that
+// can issue presigned POST policies for direct browser-to-storage uploads.
+// Handlers should type-assert a Backend to this interface and return 501 if
+// the active backend doesn't support it, the same way MultipartBackend is
+// handled.
+type PostPolicyBackend interface {
+	PresignPost(ctx context.Context, keyPrefix string, constraints PostPolicyConstraints, expireMinutes int64) (*PresignedPost, error)
+}
+
+// PostPolicyConstraints limits what a presigned POST policy will accept,
+// enforced server-side by the storage backend rather than trusted from the
+// browser.
+type PostPolicyConstraints struct {
+	MaxSizeBytes        int64
+	AllowedContentTypes []string
+}
+
+// PresignedPost is everything a browser needs to POST a file straight to
+// the backend: the form action URL and the fields (including the signature)
+// that must be submitted alongside the file.
+type PresignedPost struct {
+	URL    string
+	Fields map[string]string
+}
+
+// ValidKey reports whether key is safe to join with a tenant prefix. It
+// rejects ".." path segments, which would otherwise let a caller climb out
+// of their own prefix into another tenant's objects even though the
+// resulting path still resolves inside a backend's own root (see
+// FSBackend.path). Callers must check this before ever concatenating a
+// caller-supplied key with caller.Prefix.
+func ValidKey(key string) bool {
+	for _, seg := range strings.Split(key, "/") {
+		if seg == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// NewBackendFromEnv builds the Backend selected by STORAGE_BACKEND
+// (r2, b2, azure, gcs, fs), reading that backend's own env vars. It returns
+// (nil, nil) when STORAGE_BACKEND is unset and the legacy R2_* vars are also
+// unset, so callers can fall back to "not configured" the way they already
+// do for the single-backend setup.
+func NewBackendFromEnv() (Backend, error) {
+	kind := os.Getenv("STORAGE_BACKEND")
+	if kind == "" {
+		kind = "r2"
+	}
+
+	switch kind {
+	case "r2":
+		accountID := os.Getenv("R2_ACCOUNT_ID")
+		accessKeyID := os.Getenv("R2_ACCESS_KEY_ID")
+		secretAccessKey := os.Getenv("R2_SECRET_ACCESS_KEY")
+		bucketName := os.Getenv("R2_BUCKET_NAME")
+		if accountID == "" || accessKeyID == "" || secretAccessKey == "" || bucketName == "" {
+			return nil, nil
+		}
+		return NewR2Client(accountID, accessKeyID, secretAccessKey, bucketName)
+
+	case "b2":
+		accountID := os.Getenv("B2_ACCOUNT_ID")
+		applicationKey := os.Getenv("B2_APPLICATION_KEY")
+		bucketName := os.Getenv("B2_BUCKET_NAME")
+		if accountID == "" || applicationKey == "" || bucketName == "" {
+			return nil, nil
+		}
+		return NewB2Backend(accountID, applicationKey, bucketName)
+
+	case "azure":
+		accountName := os.Getenv("AZURE_STORAGE_ACCOUNT")
+		accountKey := os.Getenv("AZURE_STORAGE_KEY")
+		containerName := os.Getenv("AZURE_STORAGE_CONTAINER")
+		if accountName == "" || accountKey == "" || containerName == "" {
+			return nil, nil
+		}
+		return NewAzureBackend(accountName, accountKey, containerName)
+
+	case "gcs":
+		bucketName := os.Getenv("GCS_BUCKET_NAME")
+		if bucketName == "" {
+			return nil, nil
+		}
+		return NewGCSBackend(context.Background(), bucketName)
+
+	case "fs":
+		root := os.Getenv("FS_STORAGE_ROOT")
+		if root == "" {
+			root = "./data"
+		}
+		return NewFSBackend(root)
+
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_BACKEND %q (want r2, b2, azure, gcs, or fs)", kind)
+	}
+}
@@ -0,0 +1,24 @@
+package storage
+
+import "testing"
+
+func TestValidKey(t *testing.T) {
+	cases := []struct {
+		key  string
+		want bool
+	}{
+		{"images/123_photo.jpg", true},
+		{"a/b/c", true},
+		{"", true},
+		{"../etc/passwd", false},
+		{"images/../secrets", false},
+		{"images/..", false},
+		{"..", false},
+	}
+
+	for _, tc := range cases {
+		if got := ValidKey(tc.key); got != tc.want {
+			t.Errorf("ValidKey(%q) = %v, want %v", tc.key, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FSBackend is a Backend implementation backed by the local filesystem. It
+// exists so the server can run against a real backend without cloud
+// credentials, e.g. for local development and tests.
+type FSBackend struct {
+	root string
+}
+
+// NewFSBackend creates a new local filesystem backend rooted at dir. The
+// directory is created if it doesn't already exist.
+func NewFSBackend(dir string) (*FSBackend, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage root: %w", err)
+	}
+	return &FSBackend{root: dir}, nil
+}
+
+func (f *FSBackend) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	full := filepath.Join(f.root, clean)
+	if !strings.HasPrefix(full, filepath.Clean(f.root)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid key: %s", key)
+	}
+	return full, nil
+}
+
+// Put writes a file to disk under the backend's root
+func (f *FSBackend) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	full, err := f.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	out, err := os.Create(full)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, body); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// Get reads a file from disk
+func (f *FSBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	full, err := f.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(full)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return data, nil
+}
+
+// Delete removes a file from disk
+func (f *FSBackend) Delete(ctx context.Context, key string) error {
+	full, err := f.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(full); err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// List walks the root directory and returns every file whose path starts
+// with prefix
+func (f *FSBackend) List(ctx context.Context, prefix string) ([]string, error) {
+	var files []string
+
+	err := filepath.Walk(f.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(f.root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if strings.HasPrefix(key, prefix) {
+			files = append(files, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	return files, nil
+}
+
+// ListPage lists files a page at a time. Since the filesystem has no
+// native continuation token, cursor is just the offset into the sorted
+// key list, encoded as a decimal string.
+func (f *FSBackend) ListPage(ctx context.Context, prefix string, limit int32, cursor string) ([]ObjectInfo, string, bool, error) {
+	keys, err := f.List(ctx, prefix)
+	if err != nil {
+		return nil, "", false, err
+	}
+	sort.Strings(keys)
+
+	offset := 0
+	if cursor != "" {
+		offset, err = strconv.Atoi(cursor)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("invalid cursor: %s", cursor)
+		}
+	}
+	if offset > len(keys) {
+		offset = len(keys)
+	}
+
+	end := offset + int(limit)
+	if end > len(keys) {
+		end = len(keys)
+	}
+
+	items := make([]ObjectInfo, 0, end-offset)
+	for _, key := range keys[offset:end] {
+		info, err := f.Stat(ctx, key)
+		if err != nil {
+			return nil, "", false, err
+		}
+		items = append(items, info)
+	}
+
+	truncated := end < len(keys)
+	nextCursor := ""
+	if truncated {
+		nextCursor = strconv.Itoa(end)
+	}
+
+	return items, nextCursor, truncated, nil
+}
+
+// Stat returns metadata for a single file
+func (f *FSBackend) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	full, err := f.path(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	info, err := os.Stat(full)
+	if err != nil {
+		return ObjectInfo{}, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	data, err := os.ReadFile(full)
+	if err != nil {
+		return ObjectInfo{}, fmt.Errorf("failed to stat file: %w", err)
+	}
+	sum := md5.Sum(data)
+
+	return ObjectInfo{
+		Key:          key,
+		Size:         info.Size(),
+		LastModified: info.ModTime(),
+		ETag:         hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// Presign returns a plain file:// URL since there's no server to issue a
+// temporary link; it's only meant for local development.
+func (f *FSBackend) Presign(ctx context.Context, key string, expireMinutes int64) (string, error) {
+	full, err := f.path(key)
+	if err != nil {
+		return "", err
+	}
+	return "file://" + full, nil
+}
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+// GCSBackend is the Backend implementation for Google Cloud Storage.
+type GCSBackend struct {
+	client     *storage.Client
+	bucketName string
+}
+
+// NewGCSBackend creates a new Google Cloud Storage backend for the given
+// bucket. Credentials are picked up the usual way (GOOGLE_APPLICATION_CREDENTIALS
+// or the attached service account).
+func NewGCSBackend(ctx context.Context, bucketName string) (*GCSBackend, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gcs client: %w", err)
+	}
+
+	return &GCSBackend{
+		client:     client,
+		bucketName: bucketName,
+	}, nil
+}
+
+// Put uploads an object to the bucket
+func (g *GCSBackend) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	w := g.client.Bucket(g.bucketName).Object(key).NewWriter(ctx)
+	w.ContentType = contentType
+
+	if _, err := io.Copy(w, body); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+	return nil
+}
+
+// Get downloads an object from the bucket
+func (g *GCSBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	r, err := g.client.Bucket(g.bucketName).Object(key).NewReader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
+
+// Delete deletes an object from the bucket
+func (g *GCSBackend) Delete(ctx context.Context, key string) error {
+	if err := g.client.Bucket(g.bucketName).Object(key).Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// List lists objects in the bucket with optional prefix
+func (g *GCSBackend) List(ctx context.Context, prefix string) ([]string, error) {
+	var files []string
+
+	it := g.client.Bucket(g.bucketName).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files: %w", err)
+		}
+		files = append(files, attrs.Name)
+	}
+
+	return files, nil
+}
+
+// ListPage lists objects a page at a time, resuming from cursor (a GCS
+// page token) when given.
+func (g *GCSBackend) ListPage(ctx context.Context, prefix string, limit int32, cursor string) ([]ObjectInfo, string, bool, error) {
+	it := g.client.Bucket(g.bucketName).Objects(ctx, &storage.Query{Prefix: prefix})
+	pager := iterator.NewPager(it, int(limit), cursor)
+
+	var page []*storage.ObjectAttrs
+	nextCursor, err := pager.NextPage(&page)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	items := make([]ObjectInfo, 0, len(page))
+	for _, attrs := range page {
+		items = append(items, ObjectInfo{
+			Key:          attrs.Name,
+			Size:         attrs.Size,
+			LastModified: attrs.Updated,
+			ETag:         attrs.Etag,
+			ContentType:  attrs.ContentType,
+		})
+	}
+
+	return items, nextCursor, nextCursor != "", nil
+}
+
+// Stat returns metadata for a single object without fetching its body
+func (g *GCSBackend) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	attrs, err := g.client.Bucket(g.bucketName).Object(key).Attrs(ctx)
+	if err != nil {
+		return ObjectInfo{}, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	return ObjectInfo{
+		Key:          key,
+		Size:         attrs.Size,
+		LastModified: attrs.Updated,
+		ETag:         attrs.Etag,
+		ContentType:  attrs.ContentType,
+	}, nil
+}
+
+// Presign generates a signed URL for downloading the object
+func (g *GCSBackend) Presign(ctx context.Context, key string, expireMinutes int64) (string, error) {
+	url, err := g.client.Bucket(g.bucketName).SignedURL(key, &storage.SignedURLOptions{
+		Method:  "GET",
+		Expires: time.Now().Add(time.Duration(expireMinutes) * time.Minute),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create signed URL: %w", err)
+	}
+	return url, nil
+}
@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// uploadsPrefix is where resumable-upload bookkeeping metadata is kept,
+// alongside the objects themselves.
+const uploadsPrefix = ".uploads/"
+
+// CompletedPart is one finished part of a resumable multipart upload.
+type CompletedPart struct {
+	PartNumber int32  `json:"partNumber"`
+	ETag       string `json:"etag"`
+}
+
+// UploadSession tracks an in-progress resumable multipart upload. It's
+// persisted as JSON so a client can reconnect with the same session ID and
+// pick up where it left off.
+type UploadSession struct {
+	ID          string          `json:"id"`
+	Key         string          `json:"key"`
+	UploadID    string          `json:"uploadId"`
+	ContentType string          `json:"contentType"`
+	Parts       []CompletedPart `json:"parts"`
+}
+
+// MultipartBackend is implemented by backends that support resumable,
+// S3-style multipart uploads. Not every Backend needs this (the local
+// filesystem one has no use for it), so callers type-assert for it.
+type MultipartBackend interface {
+	CreateUpload(ctx context.Context, key, contentType string) (*UploadSession, error)
+	UploadPart(ctx context.Context, session *UploadSession, partNumber int32, body io.Reader) (CompletedPart, error)
+	CompleteUpload(ctx context.Context, session *UploadSession) error
+	AbortUpload(ctx context.Context, session *UploadSession) error
+	LoadUploadSession(ctx context.Context, id string) (*UploadSession, error)
+}
+
+func uploadSessionKey(id string) string {
+	return fmt.Sprintf("%s%s.json", uploadsPrefix, id)
+}
+
+func newUploadSessionID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate upload id: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func (r *R2Client) saveUploadSession(ctx context.Context, session *UploadSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to encode upload session: %w", err)
+	}
+	return r.Put(ctx, uploadSessionKey(session.ID), bytes.NewReader(data), "application/json")
+}
+
+// CreateUpload starts a new resumable multipart upload and persists its
+// session so a client can resume it later by ID.
+func (r *R2Client) CreateUpload(ctx context.Context, key, contentType string) (*UploadSession, error) {
+	out, err := r.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(r.bucketName),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create multipart upload: %w", err)
+	}
+
+	id, err := newUploadSessionID()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &UploadSession{
+		ID:          id,
+		Key:         key,
+		UploadID:    *out.UploadId,
+		ContentType: contentType,
+	}
+	if err := r.saveUploadSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// LoadUploadSession fetches a previously persisted upload session by ID, so
+// a client reconnecting after a dropped connection can see which parts it
+// already uploaded and skip them.
+func (r *R2Client) LoadUploadSession(ctx context.Context, id string) (*UploadSession, error) {
+	data, err := r.Get(ctx, uploadSessionKey(id))
+	if err != nil {
+		return nil, fmt.Errorf("upload session not found: %w", err)
+	}
+
+	var session UploadSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to decode upload session: %w", err)
+	}
+	return &session, nil
+}
+
+// UploadPart uploads a single part (5-16 MiB is the expected range) and
+// records it in the session.
+func (r *R2Client) UploadPart(ctx context.Context, session *UploadSession, partNumber int32, body io.Reader) (CompletedPart, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return CompletedPart{}, fmt.Errorf("failed to read part: %w", err)
+	}
+
+	out, err := r.client.UploadPart(ctx, &s3.UploadPartInput{
+		Bucket:     aws.String(r.bucketName),
+		Key:        aws.String(session.Key),
+		UploadId:   aws.String(session.UploadID),
+		PartNumber: aws.Int32(partNumber),
+		Body:       bytes.NewReader(data),
+	})
+	if err != nil {
+		return CompletedPart{}, fmt.Errorf("failed to upload part %d: %w", partNumber, err)
+	}
+
+	part := CompletedPart{PartNumber: partNumber, ETag: *out.ETag}
+	session.Parts = append(session.Parts, part)
+	if err := r.saveUploadSession(ctx, session); err != nil {
+		return CompletedPart{}, err
+	}
+	return part, nil
+}
+
+// CompleteUpload finishes a multipart upload once every part has been
+// received, and removes the session's bookkeeping metadata.
+func (r *R2Client) CompleteUpload(ctx context.Context, session *UploadSession) error {
+	parts := make([]types.CompletedPart, 0, len(session.Parts))
+	for _, p := range session.Parts {
+		parts = append(parts, types.CompletedPart{
+			PartNumber: aws.Int32(p.PartNumber),
+			ETag:       aws.String(p.ETag),
+		})
+	}
+
+	_, err := r.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:   aws.String(r.bucketName),
+		Key:      aws.String(session.Key),
+		UploadId: aws.String(session.UploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{
+			Parts: parts,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+
+	return r.Delete(ctx, uploadSessionKey(session.ID))
+}
+
+// AbortUpload cancels a multipart upload and cleans up its session metadata.
+func (r *R2Client) AbortUpload(ctx context.Context, session *UploadSession) error {
+	_, err := r.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(r.bucketName),
+		Key:      aws.String(session.Key),
+		UploadId: aws.String(session.UploadID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to abort multipart upload: %w", err)
+	}
+
+	return r.Delete(ctx, uploadSessionKey(session.ID))
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// postPolicyRegion and postPolicyService match the values R2 expects in a
+// SigV4 POST policy credential scope, the same "auto"/"s3" pair NewR2Client
+// already uses for request signing.
+const (
+	postPolicyRegion  = "auto"
+	postPolicyService = "s3"
+)
+
+// PresignPost builds a presigned POST policy so a browser can upload
+// directly to R2 without the request passing through this server. The
+// returned key field uses the S3 "${filename}" substitution, so the
+// browser's chosen filename is appended to keyPrefix automatically.
+func (r *R2Client) PresignPost(ctx context.Context, keyPrefix string, constraints PostPolicyConstraints, expireMinutes int64) (*PresignedPost, error) {
+	// A POST policy's conditions are ANDed together, so there's no way to
+	// express "Content-Type is one of these N values" as a single
+	// condition. Rather than silently drop the constraint down to
+	// "any content type" once there's more than one allowed type, refuse
+	// to issue a policy we can't actually enforce.
+	if len(constraints.AllowedContentTypes) > 1 {
+		return nil, fmt.Errorf("presigned POST supports at most one allowed content type, got %d", len(constraints.AllowedContentTypes))
+	}
+
+	creds, err := r.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve credentials: %w", err)
+	}
+
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+	credential := fmt.Sprintf("%s/%s/%s/%s/aws4_request", creds.AccessKeyID, dateStamp, postPolicyRegion, postPolicyService)
+
+	conditions := []interface{}{
+		map[string]string{"bucket": r.bucketName},
+		[]interface{}{"starts-with", "$key", keyPrefix},
+		map[string]string{"x-amz-algorithm": "AWS4-HMAC-SHA256"},
+		map[string]string{"x-amz-credential": credential},
+		map[string]string{"x-amz-date": amzDate},
+	}
+	if constraints.MaxSizeBytes > 0 {
+		conditions = append(conditions, []interface{}{"content-length-range", 0, constraints.MaxSizeBytes})
+	}
+	if len(constraints.AllowedContentTypes) == 1 {
+		conditions = append(conditions, map[string]string{"Content-Type": constraints.AllowedContentTypes[0]})
+	} else {
+		conditions = append(conditions, []interface{}{"starts-with", "$Content-Type", ""})
+	}
+
+	policy := map[string]interface{}{
+		"expiration": now.Add(time.Duration(expireMinutes) * time.Minute).Format(time.RFC3339),
+		"conditions": conditions,
+	}
+	policyJSON, err := json.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal policy: %w", err)
+	}
+	policyB64 := base64.StdEncoding.EncodeToString(policyJSON)
+
+	signingKey := postPolicySigningKey(creds.SecretAccessKey, dateStamp)
+	signature := hex.EncodeToString(hmacSHA256(signingKey, policyB64))
+
+	fields := map[string]string{
+		"key":              keyPrefix + "${filename}",
+		"x-amz-algorithm":  "AWS4-HMAC-SHA256",
+		"x-amz-credential": credential,
+		"x-amz-date":       amzDate,
+		"policy":           policyB64,
+		"x-amz-signature":  signature,
+	}
+	if len(constraints.AllowedContentTypes) == 1 {
+		fields["Content-Type"] = constraints.AllowedContentTypes[0]
+	}
+
+	return &PresignedPost{
+		URL:    fmt.Sprintf("%s/%s", r.endpoint, r.bucketName),
+		Fields: fields,
+	}, nil
+}
+
+// postPolicySigningKey derives the SigV4 signing key for a given date, the
+// same dateKey -> regionKey -> serviceKey -> signingKey chain used to sign
+// ordinary requests, just applied to a POST policy document instead of a
+// canonical request.
+func postPolicySigningKey(secretAccessKey, dateStamp string) []byte {
+	dateKey := hmacSHA256([]byte("AWS4"+secretAccessKey), dateStamp)
+	regionKey := hmacSHA256(dateKey, postPolicyRegion)
+	serviceKey := hmacSHA256(regionKey, postPolicyService)
+	return hmacSHA256(serviceKey, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -1,7 +1,6 @@
 package storage
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -11,12 +10,19 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
+// R2Client is the Backend implementation for Cloudflare R2 (and any other
+// S3-compatible endpoint). endpoint and credentials are kept alongside the
+// s3.Client so PresignPost can sign a POST policy by hand, the same way the
+// SDK signs ordinary requests internally.
 type R2Client struct {
-	client     *s3.Client
-	bucketName string
+	client      *s3.Client
+	bucketName  string
+	endpoint    string
+	credentials aws.CredentialsProvider
 }
 
 // NewR2Client creates a new R2 storage client
@@ -46,17 +52,19 @@ func NewR2Client(accountID, accessKeyID, secretAccessKey, bucketName string) (*R
 	})
 
 	return &R2Client{
-		client:     client,
-		bucketName: bucketName,
+		client:      client,
+		bucketName:  bucketName,
+		endpoint:    r2Endpoint,
+		credentials: credProvider,
 	}, nil
 }
 
-// UploadFile uploads a file to R2
-func (r *R2Client) UploadFile(ctx context.Context, key string, file io.Reader, contentType string) error {
+// Put uploads a file to R2
+func (r *R2Client) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
 	_, err := r.client.PutObject(ctx, &s3.PutObjectInput{
 		Bucket:      aws.String(r.bucketName),
 		Key:         aws.String(key),
-		Body:        file,
+		Body:        body,
 		ContentType: aws.String(contentType),
 	})
 	if err != nil {
@@ -65,7 +73,8 @@ func (r *R2Client) UploadFile(ctx context.Context, key string, file io.Reader, c
 	return nil
 }
 
-// UploadMultipartFile uploads a multipart file to R2
+// UploadMultipartFile streams a multipart file straight to R2 without
+// buffering it in memory first.
 func (r *R2Client) UploadMultipartFile(ctx context.Context, key string, file *multipart.FileHeader) error {
 	src, err := file.Open()
 	if err != nil {
@@ -73,23 +82,62 @@ func (r *R2Client) UploadMultipartFile(ctx context.Context, key string, file *mu
 	}
 	defer src.Close()
 
-	// Read file content
-	buf := bytes.NewBuffer(nil)
-	if _, err := io.Copy(buf, src); err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
-	}
-
 	// Determine content type
 	contentType := file.Header.Get("Content-Type")
 	if contentType == "" {
 		contentType = "application/octet-stream"
 	}
 
-	return r.UploadFile(ctx, key, buf, contentType)
+	return r.UploadStream(ctx, key, src, contentType, UploadStreamOptions{})
 }
 
-// DownloadFile downloads a file from R2
-func (r *R2Client) DownloadFile(ctx context.Context, key string) ([]byte, error) {
+// UploadStreamOptions configures UploadStream's part size and concurrency.
+// Zero values fall back to sane defaults.
+type UploadStreamOptions struct {
+	PartSizeMiB int64
+	Concurrency int
+}
+
+// UploadStream uploads r to R2 as a true streaming S3 multipart upload,
+// splitting it into PartSizeMiB-sized parts (default 8 MiB, clamped to a
+// 5-16 MiB range; S3's minimum part size is 5 MiB) and uploading them with the given
+// concurrency. Unlike Put, it never holds the whole file in memory, which
+// removes the OOM risk on large uploads.
+func (r *R2Client) UploadStream(ctx context.Context, key string, body io.Reader, contentType string, opts UploadStreamOptions) error {
+	partSize := opts.PartSizeMiB
+	if partSize <= 0 {
+		partSize = 8
+	}
+	if partSize < 5 {
+		partSize = 5
+	}
+	if partSize > 16 {
+		partSize = 16
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	uploader := manager.NewUploader(r.client, func(u *manager.Uploader) {
+		u.PartSize = partSize * 1024 * 1024
+		u.Concurrency = concurrency
+	})
+
+	_, err := uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(r.bucketName),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+	return nil
+}
+
+// Get downloads a file from R2
+func (r *R2Client) Get(ctx context.Context, key string) ([]byte, error) {
 	result, err := r.client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(r.bucketName),
 		Key:    aws.String(key),
@@ -102,8 +150,8 @@ func (r *R2Client) DownloadFile(ctx context.Context, key string) ([]byte, error)
 	return io.ReadAll(result.Body)
 }
 
-// DeleteFile deletes a file from R2
-func (r *R2Client) DeleteFile(ctx context.Context, key string) error {
+// Delete deletes a file from R2
+func (r *R2Client) Delete(ctx context.Context, key string) error {
 	_, err := r.client.DeleteObject(ctx, &s3.DeleteObjectInput{
 		Bucket: aws.String(r.bucketName),
 		Key:    aws.String(key),
@@ -114,8 +162,8 @@ func (r *R2Client) DeleteFile(ctx context.Context, key string) error {
 	return nil
 }
 
-// ListFiles lists files in the bucket with optional prefix
-func (r *R2Client) ListFiles(ctx context.Context, prefix string) ([]string, error) {
+// List lists files in the bucket with optional prefix
+func (r *R2Client) List(ctx context.Context, prefix string) ([]string, error) {
 	var files []string
 
 	input := &s3.ListObjectsV2Input{
@@ -137,8 +185,11 @@ func (r *R2Client) ListFiles(ctx context.Context, prefix string) ([]string, erro
 	return files, nil
 }
 
-// ListFilesPage lists files with pagination support using continuation tokens
-func (r *R2Client) ListFilesPage(ctx context.Context, prefix string, limit int32, continuationToken string) ([]string, string, bool, error) {
+// ListPage lists objects a page at a time using continuation tokens,
+// returning size/etag/last-modified straight from the ListObjectsV2
+// response so callers don't need a HeadObject round trip per key.
+// ContentType isn't available from ListObjectsV2, so it's left blank.
+func (r *R2Client) ListPage(ctx context.Context, prefix string, limit int32, cursor string) ([]ObjectInfo, string, bool, error) {
 	input := &s3.ListObjectsV2Input{
 		Bucket:  aws.String(r.bucketName),
 		MaxKeys: aws.Int32(limit),
@@ -146,8 +197,8 @@ func (r *R2Client) ListFilesPage(ctx context.Context, prefix string, limit int32
 	if prefix != "" {
 		input.Prefix = aws.String(prefix)
 	}
-	if continuationToken != "" {
-		input.ContinuationToken = aws.String(continuationToken)
+	if cursor != "" {
+		input.ContinuationToken = aws.String(cursor)
 	}
 
 	result, err := r.client.ListObjectsV2(ctx, input)
@@ -155,9 +206,19 @@ func (r *R2Client) ListFilesPage(ctx context.Context, prefix string, limit int32
 		return nil, "", false, fmt.Errorf("failed to list files: %w", err)
 	}
 
-	keys := make([]string, 0, len(result.Contents))
+	items := make([]ObjectInfo, 0, len(result.Contents))
 	for _, object := range result.Contents {
-		keys = append(keys, *object.Key)
+		info := ObjectInfo{Key: *object.Key}
+		if object.Size != nil {
+			info.Size = *object.Size
+		}
+		if object.LastModified != nil {
+			info.LastModified = *object.LastModified
+		}
+		if object.ETag != nil {
+			info.ETag = *object.ETag
+		}
+		items = append(items, info)
 	}
 
 	nextCursor := ""
@@ -165,11 +226,42 @@ func (r *R2Client) ListFilesPage(ctx context.Context, prefix string, limit int32
 		nextCursor = *result.NextContinuationToken
 	}
 
-	return keys, nextCursor, *result.IsTruncated, nil
+	truncated := false
+	if result.IsTruncated != nil {
+		truncated = *result.IsTruncated
+	}
+
+	return items, nextCursor, truncated, nil
+}
+
+// Stat returns metadata for a single object without fetching its body
+func (r *R2Client) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	result, err := r.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(r.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return ObjectInfo{}, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	info := ObjectInfo{Key: key}
+	if result.ContentLength != nil {
+		info.Size = *result.ContentLength
+	}
+	if result.LastModified != nil {
+		info.LastModified = *result.LastModified
+	}
+	if result.ETag != nil {
+		info.ETag = *result.ETag
+	}
+	if result.ContentType != nil {
+		info.ContentType = *result.ContentType
+	}
+	return info, nil
 }
 
-// GetPresignedURL generates a presigned URL for downloading
-func (r *R2Client) GetPresignedURL(ctx context.Context, key string, expireMinutes int64) (string, error) {
+// Presign generates a presigned URL for downloading
+func (r *R2Client) Presign(ctx context.Context, key string, expireMinutes int64) (string, error) {
 	presignClient := s3.NewPresignClient(r.client)
 
 	request, err := presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
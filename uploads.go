@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"go_server/accesskey"
+	"go_server/storage"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// multipartBackend returns the active store as a storage.MultipartBackend,
+// or an error response if the configured backend doesn't support resumable
+// multipart uploads.
+func multipartBackend(c echo.Context) (storage.MultipartBackend, error) {
+	if store == nil {
+		return nil, c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error: "Storage service not configured",
+		})
+	}
+	mb, ok := store.(storage.MultipartBackend)
+	if !ok {
+		return nil, c.JSON(http.StatusNotImplemented, ErrorResponse{
+			Error: "Configured storage backend does not support resumable uploads",
+		})
+	}
+	return mb, nil
+}
+
+// ownsSession reports whether session belongs to caller, i.e. its key
+// lives under caller's own prefix. Every handler below must check this
+// before acting on a session loaded by client-supplied ID, since session
+// IDs carry no caller identity of their own.
+func ownsSession(caller *accesskey.Key, session *storage.UploadSession) bool {
+	return strings.HasPrefix(session.Key, caller.Prefix)
+}
+
+// stripSessionPrefix returns a copy of session with caller's prefix
+// trimmed off its key, since callers never see or send their own prefix.
+func stripSessionPrefix(caller *accesskey.Key, session *storage.UploadSession) storage.UploadSession {
+	stripped := *session
+	stripped.Key = strings.TrimPrefix(session.Key, caller.Prefix)
+	return stripped
+}
+
+// InitUploadRequest is the body of POST /api/uploads/init
+type InitUploadRequest struct {
+	Key         string `json:"key"`
+	ContentType string `json:"contentType"`
+}
+
+// initUpload starts a new resumable multipart upload
+func initUpload(c echo.Context) error {
+	mb, errResp := multipartBackend(c)
+	if mb == nil {
+		return errResp
+	}
+
+	caller := callerKey(c)
+	if caller == nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+	}
+	if !caller.Allows(accesskey.OpWrite) {
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: "Access key is not scoped for write",
+		})
+	}
+
+	var req InitUploadRequest
+	if err := c.Bind(&req); err != nil || req.Key == "" {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "A non-empty key is required",
+		})
+	}
+	if !storage.ValidKey(req.Key) {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid key",
+		})
+	}
+	if req.ContentType == "" {
+		req.ContentType = "application/octet-stream"
+	}
+
+	session, err := mb.CreateUpload(c.Request().Context(), caller.Prefix+req.Key, req.ContentType)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: fmt.Sprintf("Failed to start upload: %v", err),
+		})
+	}
+
+	return c.JSON(http.StatusOK, stripSessionPrefix(caller, session))
+}
+
+// getUpload returns the session for an in-progress upload, including which
+// parts have already been received, so a reconnecting client can skip them.
+func getUpload(c echo.Context) error {
+	mb, errResp := multipartBackend(c)
+	if mb == nil {
+		return errResp
+	}
+
+	caller := callerKey(c)
+	if caller == nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+	}
+	if !caller.Allows(accesskey.OpRead) {
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: "Access key is not scoped for read",
+		})
+	}
+
+	session, err := mb.LoadUploadSession(c.Request().Context(), c.Param("id"))
+	if err != nil || !ownsSession(caller, session) {
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Upload session not found",
+		})
+	}
+
+	return c.JSON(http.StatusOK, stripSessionPrefix(caller, session))
+}
+
+// uploadPart uploads a single part of an in-progress multipart upload
+func uploadPart(c echo.Context) error {
+	mb, errResp := multipartBackend(c)
+	if mb == nil {
+		return errResp
+	}
+
+	caller := callerKey(c)
+	if caller == nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+	}
+	if !caller.Allows(accesskey.OpWrite) {
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: "Access key is not scoped for write",
+		})
+	}
+
+	partNumber, err := strconv.Atoi(c.QueryParam("partNumber"))
+	if err != nil || partNumber < 1 {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "A positive partNumber query parameter is required",
+		})
+	}
+
+	session, err := mb.LoadUploadSession(c.Request().Context(), c.Param("id"))
+	if err != nil || !ownsSession(caller, session) {
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Upload session not found",
+		})
+	}
+
+	part, err := mb.UploadPart(c.Request().Context(), session, int32(partNumber), c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: fmt.Sprintf("Failed to upload part: %v", err),
+		})
+	}
+
+	return c.JSON(http.StatusOK, part)
+}
+
+// completeUpload finishes a multipart upload once every part is in
+func completeUpload(c echo.Context) error {
+	mb, errResp := multipartBackend(c)
+	if mb == nil {
+		return errResp
+	}
+
+	caller := callerKey(c)
+	if caller == nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+	}
+	if !caller.Allows(accesskey.OpWrite) {
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: "Access key is not scoped for write",
+		})
+	}
+
+	session, err := mb.LoadUploadSession(c.Request().Context(), c.Param("id"))
+	if err != nil || !ownsSession(caller, session) {
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Upload session not found",
+		})
+	}
+
+	if err := mb.CompleteUpload(c.Request().Context(), session); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: fmt.Sprintf("Failed to complete upload: %v", err),
+		})
+	}
+
+	return c.JSON(http.StatusOK, UploadResponse{
+		Key:     strings.TrimPrefix(session.Key, caller.Prefix),
+		Message: "Upload completed successfully",
+	})
+}
+
+// abortUpload cancels an in-progress multipart upload
+func abortUpload(c echo.Context) error {
+	mb, errResp := multipartBackend(c)
+	if mb == nil {
+		return errResp
+	}
+
+	caller := callerKey(c)
+	if caller == nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+	}
+	if !caller.Allows(accesskey.OpWrite) {
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: "Access key is not scoped for write",
+		})
+	}
+
+	session, err := mb.LoadUploadSession(c.Request().Context(), c.Param("id"))
+	if err != nil || !ownsSession(caller, session) {
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Upload session not found",
+		})
+	}
+
+	if err := mb.AbortUpload(c.Request().Context(), session); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: fmt.Sprintf("Failed to abort upload: %v", err),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Upload aborted",
+	})
+}